@@ -0,0 +1,170 @@
+// Package errkit provides structured errors that carry a machine-checkable
+// Code alongside a captured stack trace, so callers can branch on what went
+// wrong (errkit.CodeOf) instead of matching error message substrings, while
+// still getting a full trace when a human needs to read %+v in a log.
+package errkit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+)
+
+// Code classifies why an operation failed, independent of its message, so
+// callers can decide how to react (retry, surface to a user, alert) without
+// parsing strings.
+type Code int
+
+const (
+	// CodeUnknown is the zero value, returned by CodeOf for errors that
+	// were never classified.
+	CodeUnknown Code = iota
+	// CodeInvalidInput marks a failure caused by bad caller input.
+	CodeInvalidInput
+	// CodeIO marks a failure talking to disk, network, or another external
+	// resource.
+	CodeIO
+	// CodeTransient marks a failure that may succeed if retried.
+	CodeTransient
+	// CodePermanent marks a failure that will not succeed on retry.
+	CodePermanent
+	// CodeNotFound marks a failure because the requested thing does not
+	// exist.
+	CodeNotFound
+)
+
+// String renders the Code's name, used by Error and by %v formatting.
+func (c Code) String() string {
+	switch c {
+	case CodeInvalidInput:
+		return "invalid_input"
+	case CodeIO:
+		return "io"
+	case CodeTransient:
+		return "transient"
+	case CodePermanent:
+		return "permanent"
+	case CodeNotFound:
+		return "not_found"
+	default:
+		return "unknown"
+	}
+}
+
+// Frame is one resolved entry of an Error's captured stack trace.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// Error is a structured error: a Code, a message, an optional wrapped cause,
+// optional key-value fields, and the stack at the point it was created.
+// Construct one with New, Wrap, or WithFields rather than this struct
+// literal directly.
+type Error struct {
+	code   Code
+	msg    string
+	cause  error
+	fields []any
+	pcs    []uintptr
+}
+
+// callers captures the program counters for the stack above errkit's own
+// constructor, so StackTrace points at the caller's code rather than at
+// New/Wrap/WithFields themselves.
+func callers() []uintptr {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	return pcs[:n]
+}
+
+// New creates an Error with the given Code and message, capturing the
+// current stack.
+func New(code Code, msg string) *Error {
+	return &Error{code: code, msg: msg, pcs: callers()}
+}
+
+// Wrap creates an Error with the given Code and message that wraps err,
+// preserving it for errors.Is/errors.As and capturing the current stack.
+func Wrap(err error, code Code, msg string) *Error {
+	return &Error{code: code, msg: msg, cause: err, pcs: callers()}
+}
+
+// WithFields attaches key-value pairs to err for structured logging. If err
+// is already an *Error the fields are attached to a new Error wrapping it
+// (the original is left untouched); otherwise a CodeUnknown Error is
+// created to carry them. kv is interpreted as alternating keys and values,
+// same convention as structured loggers such as log/slog.
+func WithFields(err error, kv ...any) error {
+	return &Error{code: CodeOf(err), cause: err, fields: kv, pcs: callers()}
+}
+
+// Error returns the message, including any wrapped cause's message, but
+// never the stack trace - use Format's %+v verb or StackTrace for that.
+func (e *Error) Error() string {
+	switch {
+	case e.msg == "" && e.cause != nil:
+		return e.cause.Error()
+	case e.cause != nil:
+		return fmt.Sprintf("%s: %s", e.msg, e.cause.Error())
+	default:
+		return e.msg
+	}
+}
+
+// Unwrap returns the wrapped cause, if any, so errors.Is and errors.As see
+// through an Error to whatever it wraps.
+func (e *Error) Unwrap() error { return e.cause }
+
+// Code returns the error's classification.
+func (e *Error) Code() Code { return e.code }
+
+// Fields returns the key-value pairs attached via WithFields.
+func (e *Error) Fields() []any { return e.fields }
+
+// StackTrace resolves the captured program counters into Frames. Resolution
+// is deferred to this call (rather than done at construction) because
+// runtime.CallersFrames does symbol lookups that are wasted work for the
+// overwhelming majority of errors that are never printed with %+v.
+func (e *Error) StackTrace() []Frame {
+	frames := runtime.CallersFrames(e.pcs)
+	var out []Frame
+	for {
+		frame, more := frames.Next()
+		out = append(out, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+// Format implements fmt.Formatter. %v and %s render the same as Error(); %+v
+// additionally appends the captured stack trace, one frame per line.
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(s, e.Error())
+		if s.Flag('+') {
+			for _, f := range e.StackTrace() {
+				fmt.Fprintf(s, "\n\t%s\n\t\t%s:%d", f.Function, f.File, f.Line)
+			}
+		}
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+// CodeOf walks err's chain via errors.As and returns the Code of the first
+// *Error found, or CodeUnknown if err does not wrap one.
+func CodeOf(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return CodeUnknown
+}
@@ -0,0 +1,114 @@
+package errkit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewAndCodeOf(t *testing.T) {
+	err := New(CodeNotFound, "widget missing")
+	if got := CodeOf(err); got != CodeNotFound {
+		t.Errorf("CodeOf(New(...)) = %v, want %v", got, CodeNotFound)
+	}
+	if err.Error() != "widget missing" {
+		t.Errorf("Error() = %q, want %q", err.Error(), "widget missing")
+	}
+}
+
+func TestWrapPreservesChainForErrorsIs(t *testing.T) {
+	sentinel := errors.New("disk full")
+	wrapped := Wrap(sentinel, CodeIO, "writing report")
+
+	if !errors.Is(wrapped, sentinel) {
+		t.Error("errors.Is(wrapped, sentinel) = false, want true")
+	}
+	if got := CodeOf(wrapped); got != CodeIO {
+		t.Errorf("CodeOf(wrapped) = %v, want %v", got, CodeIO)
+	}
+	want := "writing report: disk full"
+	if wrapped.Error() != want {
+		t.Errorf("Error() = %q, want %q", wrapped.Error(), want)
+	}
+}
+
+func TestCodeSurvivesFmtErrorfWrapping(t *testing.T) {
+	inner := New(CodeTransient, "connection reset")
+	outer := fmt.Errorf("sending message: %w", inner)
+
+	if got := CodeOf(outer); got != CodeTransient {
+		t.Errorf("CodeOf(outer) = %v, want %v", got, CodeTransient)
+	}
+
+	var asErr *Error
+	if !errors.As(outer, &asErr) {
+		t.Fatal("errors.As(outer, &asErr) = false, want true")
+	}
+	if asErr != inner {
+		t.Error("errors.As did not recover the original *Error")
+	}
+}
+
+func TestCodeOfReturnsUnknownForPlainErrors(t *testing.T) {
+	if got := CodeOf(errors.New("plain")); got != CodeUnknown {
+		t.Errorf("CodeOf(plain error) = %v, want %v", got, CodeUnknown)
+	}
+}
+
+func TestWithFieldsAttachesWithoutChangingMessage(t *testing.T) {
+	base := New(CodeInvalidInput, "bad request")
+	withFields := WithFields(base, "user_id", 42, "field", "email")
+
+	if withFields.Error() != base.Error() {
+		t.Errorf("Error() = %q, want unchanged %q", withFields.Error(), base.Error())
+	}
+
+	var e *Error
+	if !errors.As(withFields, &e) {
+		t.Fatal("errors.As(withFields, &e) = false, want true")
+	}
+	fields := e.Fields()
+	if len(fields) != 4 || fields[0] != "user_id" || fields[1] != 42 {
+		t.Errorf("Fields() = %v, want [user_id 42 field email]", fields)
+	}
+	if got := CodeOf(withFields); got != CodeInvalidInput {
+		t.Errorf("CodeOf(withFields) = %v, want %v", got, CodeInvalidInput)
+	}
+}
+
+func TestStackTracePointsAtOrigin(t *testing.T) {
+	err := newAtHelper()
+	frames := err.StackTrace()
+	if len(frames) == 0 {
+		t.Fatal("StackTrace() returned no frames")
+	}
+	if !strings.Contains(frames[0].Function, "newAtHelper") {
+		t.Errorf("StackTrace()[0].Function = %q, want it to contain %q", frames[0].Function, "newAtHelper")
+	}
+	if !strings.HasSuffix(frames[0].File, "errkit_test.go") {
+		t.Errorf("StackTrace()[0].File = %q, want errkit_test.go", frames[0].File)
+	}
+}
+
+// newAtHelper exists only so TestStackTracePointsAtOrigin has a named frame
+// to look for at the top of the captured stack.
+func newAtHelper() *Error {
+	return New(CodeIO, "boom")
+}
+
+func TestFormatPlusVIncludesStackTrace(t *testing.T) {
+	err := New(CodeIO, "boom")
+	plain := fmt.Sprintf("%v", err)
+	verbose := fmt.Sprintf("%+v", err)
+
+	if plain != "boom" {
+		t.Errorf("%%v = %q, want %q", plain, "boom")
+	}
+	if !strings.HasPrefix(verbose, "boom\n") {
+		t.Errorf("%%+v = %q, want it to start with %q", verbose, "boom\\n")
+	}
+	if !strings.Contains(verbose, "errkit_test.go") {
+		t.Errorf("%%+v = %q, want it to reference errkit_test.go", verbose)
+	}
+}
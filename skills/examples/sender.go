@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+
+	"github.com/darrenoakey/claude-skill-golang/skills/errkit"
 )
 
 // Message holds notification content. Unexported fields ensure construction
@@ -16,13 +19,19 @@ type Message struct {
 }
 
 // NewMessage creates a validated Message. Returns an error if either field
-// is empty, since a notification without a recipient or body is meaningless.
+// is empty, since a notification without a recipient or body is meaningless,
+// or if to contains a carriage return or line feed, since a Sender that
+// interpolates to into a protocol command line or header (SMTPSender) would
+// otherwise let it inject extra commands or headers.
 func NewMessage(to, body string) (Message, error) {
 	if to == "" {
-		return Message{}, fmt.Errorf("to cannot be empty")
+		return Message{}, errkit.New(errkit.CodeInvalidInput, "to cannot be empty")
+	}
+	if strings.ContainsAny(to, "\r\n") {
+		return Message{}, errkit.New(errkit.CodeInvalidInput, "to must not contain CR or LF")
 	}
 	if body == "" {
-		return Message{}, fmt.Errorf("body cannot be empty")
+		return Message{}, errkit.New(errkit.CodeInvalidInput, "body cannot be empty")
 	}
 	return Message{to: to, body: body}, nil
 }
@@ -62,7 +71,10 @@ func (f *FileSender) Send(_ context.Context, msg Message) error {
 
 	path := filepath.Join(f.dir, fmt.Sprintf("msg_%03d.txt", n))
 	content := fmt.Sprintf("To: %s\n\n%s\n", msg.To(), msg.Body())
-	return os.WriteFile(path, []byte(content), 0644)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return errkit.Wrap(err, errkit.CodeIO, "writing message file")
+	}
+	return nil
 }
 
 // MemorySender collects messages in memory. Useful for environments where
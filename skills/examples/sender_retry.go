@@ -0,0 +1,182 @@
+package examples
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Action tells RetrySender how to respond to a failed Send, as decided by
+// the Classifier passed to NewRetrySender.
+type Action int
+
+const (
+	// Retry defers to the Strategy to decide whether and how long to wait
+	// before the next attempt.
+	Retry Action = iota
+	// Abort means the error is permanent: stop immediately and return it
+	// without consulting the Strategy.
+	Abort
+	// Stop means retrying is no longer useful right now (independent of
+	// whether the error itself looks permanent): stop immediately and
+	// return it without consulting the Strategy.
+	Stop
+)
+
+// Classifier maps a Send error to the Action RetrySender should take.
+type Classifier func(err error) Action
+
+// Strategy decides, after a failed attempt, whether RetrySender should try
+// again and how long to wait first.
+type Strategy interface {
+	// Next is called with the 1-indexed attempt number that just failed
+	// with err. It returns the delay before the next attempt and whether a
+	// retry should happen at all.
+	Next(attempt int, err error) (delay time.Duration, retry bool)
+}
+
+// StrategyFunc adapts a plain function to the Strategy interface.
+type StrategyFunc func(attempt int, err error) (time.Duration, bool)
+
+// Next calls f.
+func (f StrategyFunc) Next(attempt int, err error) (time.Duration, bool) {
+	return f(attempt, err)
+}
+
+// MaxAttempts stops retrying once n attempts have been made in total.
+func MaxAttempts(n int) Strategy {
+	return StrategyFunc(func(attempt int, _ error) (time.Duration, bool) {
+		return 0, attempt < n
+	})
+}
+
+// FixedDelay waits d between every attempt, with no upper bound on retries
+// by itself; combine it with MaxAttempts or Deadline via Compose.
+func FixedDelay(d time.Duration) Strategy {
+	return StrategyFunc(func(int, error) (time.Duration, bool) {
+		return d, true
+	})
+}
+
+// ExponentialBackoff waits base*2^attempt, capped at cap, then randomizes
+// the result by +/- jitter (a fraction in [0, 1]) so that many retrying
+// callers do not all wake up at the same instant.
+func ExponentialBackoff(base, cap time.Duration, jitter float64) Strategy {
+	return StrategyFunc(func(attempt int, _ error) (time.Duration, bool) {
+		delay := base * time.Duration(uint64(1)<<uint(attempt))
+		if delay <= 0 || delay > cap {
+			delay = cap
+		}
+		if jitter > 0 {
+			factor := 1 + jitter*(2*rand.Float64()-1)
+			delay = time.Duration(float64(delay) * factor)
+		}
+		return delay, true
+	})
+}
+
+// Deadline stops retrying once t has passed.
+func Deadline(t time.Time) Strategy {
+	return StrategyFunc(func(int, error) (time.Duration, bool) {
+		return 0, time.Now().Before(t)
+	})
+}
+
+// Compose combines multiple strategies into one: the resulting delay is the
+// maximum delay any strategy requests, and the combination stops retrying
+// as soon as any single strategy signals stop.
+func Compose(strategies ...Strategy) Strategy {
+	return StrategyFunc(func(attempt int, err error) (time.Duration, bool) {
+		var maxDelay time.Duration
+		for _, s := range strategies {
+			delay, retry := s.Next(attempt, err)
+			if !retry {
+				return 0, false
+			}
+			if delay > maxDelay {
+				maxDelay = delay
+			}
+		}
+		return maxDelay, true
+	})
+}
+
+// retryAttemptKey is the context key RetrySender uses to record which
+// attempt is in flight.
+type retryAttemptKey struct{}
+
+// RetryAttempt returns the 1-indexed attempt number RetrySender set on the
+// context passed to the wrapped Sender's Send, or 0 if ctx was not derived
+// from a RetrySender call. Useful for logging and tracing inside an inner
+// Sender.
+func RetryAttempt(ctx context.Context) int {
+	n, _ := ctx.Value(retryAttemptKey{}).(int)
+	return n
+}
+
+// RetrySender wraps a Sender and retries failed Send calls according to a
+// Strategy and Classifier. Construct one with NewRetrySender.
+type RetrySender struct {
+	inner    Sender
+	strategy Strategy
+	classify Classifier
+
+	mu       sync.Mutex
+	attempts int
+}
+
+// NewRetrySender creates a RetrySender that retries inner's Send according
+// to strategy, consulting classify after each failure to decide whether the
+// error is worth retrying at all.
+func NewRetrySender(inner Sender, strategy Strategy, classify Classifier) *RetrySender {
+	return &RetrySender{inner: inner, strategy: strategy, classify: classify}
+}
+
+// Send attempts delivery through the inner Sender, retrying on failure per
+// the configured Strategy and Classifier. It respects ctx.Done() between
+// attempts, returning ctx.Err() if the context is canceled while waiting
+// for the next attempt.
+func (r *RetrySender) Send(ctx context.Context, msg Message) error {
+	for attempt := 1; ; attempt++ {
+		attemptCtx := context.WithValue(ctx, retryAttemptKey{}, attempt)
+		err := r.inner.Send(attemptCtx, msg)
+		r.recordAttempt()
+		if err == nil {
+			return nil
+		}
+
+		switch r.classify(err) {
+		case Abort, Stop:
+			return err
+		}
+
+		delay, retry := r.strategy.Next(attempt, err)
+		if !retry {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// recordAttempt increments the attempt counter under lock.
+func (r *RetrySender) recordAttempt() {
+	r.mu.Lock()
+	r.attempts++
+	r.mu.Unlock()
+}
+
+// Attempts returns how many times Send has invoked the inner Sender across
+// all calls, for observability (metrics, logging).
+func (r *RetrySender) Attempts() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts
+}
@@ -0,0 +1,158 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubSender fails its first `failures` calls, then succeeds. It is a real
+// Sender, not a mock framework, so it can be inspected after the fact like
+// the other Sender implementations in this package.
+type stubSender struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	err      error
+	lastCtx  context.Context
+}
+
+func (s *stubSender) Send(ctx context.Context, _ Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	s.lastCtx = ctx
+	if s.calls <= s.failures {
+		return s.err
+	}
+	return nil
+}
+
+func (s *stubSender) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func testMsg(t *testing.T) Message {
+	t.Helper()
+	msg, err := NewMessage("bob@example.com", "Body")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	return msg
+}
+
+func TestRetrySenderSucceedsAfterFailures(t *testing.T) {
+	inner := &stubSender{failures: 2, err: errors.New("transient failure")}
+	sender := NewRetrySender(inner, Compose(MaxAttempts(5), FixedDelay(time.Millisecond)), func(error) Action {
+		return Retry
+	})
+
+	if err := sender.Send(context.Background(), testMsg(t)); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if sender.Attempts() != 3 {
+		t.Errorf("Attempts() = %d, want 3", sender.Attempts())
+	}
+}
+
+func TestRetrySenderAbortsOnPermanentError(t *testing.T) {
+	permanent := errors.New("permanent failure")
+	inner := &stubSender{failures: 100, err: permanent}
+	sender := NewRetrySender(inner, Compose(MaxAttempts(5), FixedDelay(time.Millisecond)), func(err error) Action {
+		if errors.Is(err, permanent) {
+			return Abort
+		}
+		return Retry
+	})
+
+	err := sender.Send(context.Background(), testMsg(t))
+	if !errors.Is(err, permanent) {
+		t.Fatalf("Send() error = %v, want %v", err, permanent)
+	}
+	if sender.Attempts() != 1 {
+		t.Errorf("Attempts() = %d, want 1 (no retries after Abort)", sender.Attempts())
+	}
+}
+
+func TestRetrySenderGivesUpWhenStrategyStops(t *testing.T) {
+	inner := &stubSender{failures: 100, err: errors.New("always fails")}
+	sender := NewRetrySender(inner, Compose(MaxAttempts(2), FixedDelay(time.Millisecond)), func(error) Action {
+		return Retry
+	})
+
+	if err := sender.Send(context.Background(), testMsg(t)); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if sender.Attempts() != 2 {
+		t.Errorf("Attempts() = %d, want 2", sender.Attempts())
+	}
+}
+
+func TestRetrySenderCancelsPromptlyDuringBackoff(t *testing.T) {
+	inner := &stubSender{failures: 100, err: errors.New("always fails")}
+	sender := NewRetrySender(inner, Compose(MaxAttempts(100), FixedDelay(time.Hour)), func(error) Action {
+		return Retry
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := sender.Send(ctx, testMsg(t))
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Send() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Send took %v to return after cancellation, want prompt exit", elapsed)
+	}
+	if inner.callCount() != 1 {
+		t.Errorf("inner Sender was called %d times, want 1", inner.callCount())
+	}
+}
+
+func TestRetrySenderPassesPerAttemptContext(t *testing.T) {
+	inner := &stubSender{failures: 2, err: errors.New("transient")}
+	sender := NewRetrySender(inner, Compose(MaxAttempts(5), FixedDelay(time.Millisecond)), func(error) Action {
+		return Retry
+	})
+
+	if err := sender.Send(context.Background(), testMsg(t)); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got := RetryAttempt(inner.lastCtx); got != 3 {
+		t.Errorf("RetryAttempt(lastCtx) = %d, want 3", got)
+	}
+}
+
+func TestComposeStopsOnAnyStrategySignal(t *testing.T) {
+	strategy := Compose(MaxAttempts(1), FixedDelay(time.Millisecond))
+	_, retry := strategy.Next(1, errors.New("fail"))
+	if retry {
+		t.Error("Compose(MaxAttempts(1), ...).Next(1, ...) retry = true, want false")
+	}
+}
+
+func TestExponentialBackoffRespectsCap(t *testing.T) {
+	strategy := ExponentialBackoff(time.Millisecond, 10*time.Millisecond, 0)
+	delay, retry := strategy.Next(10, errors.New("fail"))
+	if !retry {
+		t.Fatal("expected retry = true")
+	}
+	if delay != 10*time.Millisecond {
+		t.Errorf("delay = %v, want capped at 10ms", delay)
+	}
+}
+
+func TestDeadlineStopsAfterItPasses(t *testing.T) {
+	strategy := Deadline(time.Now().Add(-time.Second))
+	_, retry := strategy.Next(1, errors.New("fail"))
+	if retry {
+		t.Error("Deadline in the past: retry = true, want false")
+	}
+}
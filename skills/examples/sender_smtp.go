@@ -0,0 +1,347 @@
+package examples
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// SMTPTLSMode selects how SMTPSender secures its connection to the server.
+type SMTPTLSMode int
+
+const (
+	// SMTPTLSNone sends mail over a plaintext connection.
+	SMTPTLSNone SMTPTLSMode = iota
+	// SMTPTLSImplicit wraps the connection in TLS before speaking SMTP,
+	// as used on the traditional SMTPS port.
+	SMTPTLSImplicit
+	// SMTPTLSStartTLS begins in plaintext and upgrades to TLS via the
+	// STARTTLS command once the server advertises support for it.
+	SMTPTLSStartTLS
+)
+
+// SMTPAuthType selects the SASL mechanism SMTPSender uses to authenticate.
+type SMTPAuthType int
+
+const (
+	// SMTPAuthNone skips authentication entirely.
+	SMTPAuthNone SMTPAuthType = iota
+	// SMTPAuthPlain authenticates via AUTH PLAIN.
+	SMTPAuthPlain
+	// SMTPAuthLogin authenticates via AUTH LOGIN.
+	SMTPAuthLogin
+	// SMTPAuthCRAMMD5 authenticates via AUTH CRAM-MD5.
+	SMTPAuthCRAMMD5
+)
+
+// SMTPErrorClass reports whether an SMTPError is worth retrying.
+type SMTPErrorClass int
+
+const (
+	// SMTPErrorUnknown covers replies outside the 4xx/5xx ranges.
+	SMTPErrorUnknown SMTPErrorClass = iota
+	// SMTPErrorTransient is a 4xx reply: the same request may succeed later.
+	SMTPErrorTransient
+	// SMTPErrorPermanent is a 5xx reply: retrying without changes will fail
+	// the same way.
+	SMTPErrorPermanent
+)
+
+// SMTPError reports an SMTP reply code and message, classified as transient
+// or permanent so callers can decide whether to retry the Send.
+type SMTPError struct {
+	Code  int
+	Msg   string
+	Class SMTPErrorClass
+}
+
+// newSMTPError classifies code into SMTPErrorTransient or SMTPErrorPermanent
+// per RFC 5321 (4xx is transient, 5xx is permanent).
+func newSMTPError(code int, msg string) *SMTPError {
+	class := SMTPErrorUnknown
+	switch {
+	case code >= 400 && code < 500:
+		class = SMTPErrorTransient
+	case code >= 500 && code < 600:
+		class = SMTPErrorPermanent
+	}
+	return &SMTPError{Code: code, Msg: msg, Class: class}
+}
+
+func (e *SMTPError) Error() string {
+	return fmt.Sprintf("smtp: %d %s", e.Code, e.Msg)
+}
+
+// Temporary reports whether the failure is transient (4xx) and may succeed
+// if the caller retries.
+func (e *SMTPError) Temporary() bool { return e.Class == SMTPErrorTransient }
+
+// smtpErrorFrom converts a *textproto.Error produced by a failed
+// ReadResponse into a classified *SMTPError. Errors that are not SMTP reply
+// failures (connection resets, timeouts) pass through unchanged.
+func smtpErrorFrom(err error) error {
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) {
+		return newSMTPError(tpErr.Code, tpErr.Msg)
+	}
+	return fmt.Errorf("smtp: %w", err)
+}
+
+// SMTPSender delivers notifications via SMTP. It implements Sender.
+// Construct one with NewSMTPSender.
+type SMTPSender struct {
+	host     string
+	port     int
+	tlsMode  SMTPTLSMode
+	auth     SMTPAuthType
+	username string
+	password string
+	helo     string
+	from     string
+	subject  string
+	dialer   *net.Dialer
+}
+
+// SMTPOption configures an SMTPSender at construction time.
+type SMTPOption func(*SMTPSender)
+
+// WithSMTPTLSMode sets how the connection is secured. The default is
+// SMTPTLSNone.
+func WithSMTPTLSMode(mode SMTPTLSMode) SMTPOption {
+	return func(s *SMTPSender) { s.tlsMode = mode }
+}
+
+// WithSMTPAuth configures SASL authentication using authType and the given
+// credentials. The default is SMTPAuthNone, which skips the AUTH exchange.
+func WithSMTPAuth(authType SMTPAuthType, username, password string) SMTPOption {
+	return func(s *SMTPSender) {
+		s.auth = authType
+		s.username = username
+		s.password = password
+	}
+}
+
+// WithSMTPHELO sets the hostname announced in the EHLO greeting. The default
+// is "localhost".
+func WithSMTPHELO(helo string) SMTPOption {
+	return func(s *SMTPSender) { s.helo = helo }
+}
+
+// WithSMTPFrom sets the envelope and header From address. The default is
+// "noreply@localhost".
+func WithSMTPFrom(from string) SMTPOption {
+	return func(s *SMTPSender) { s.from = from }
+}
+
+// WithSMTPSubject sets the header Subject line applied to every message sent
+// through this SMTPSender. The default is "Notification".
+func WithSMTPSubject(subject string) SMTPOption {
+	return func(s *SMTPSender) { s.subject = subject }
+}
+
+// WithSMTPDialer overrides the net.Dialer used to open the connection,
+// letting tests inject a dialer that connects to a fake net.Listener instead
+// of a real SMTP server.
+func WithSMTPDialer(dialer *net.Dialer) SMTPOption {
+	return func(s *SMTPSender) { s.dialer = dialer }
+}
+
+// NewSMTPSender creates an SMTPSender targeting host:port, applying opts in
+// order.
+func NewSMTPSender(host string, port int, opts ...SMTPOption) *SMTPSender {
+	s := &SMTPSender{
+		host:    host,
+		port:    port,
+		helo:    "localhost",
+		from:    "noreply@localhost",
+		subject: "Notification",
+		dialer:  &net.Dialer{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Send delivers msg over SMTP. It honors ctx cancellation during both dial
+// and the command exchange: the underlying connection is closed as soon as
+// ctx is done, and the in-flight attempt returns ctx.Err().
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	conn, err := s.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("smtp: dial %s: %w", addr, err)
+	}
+
+	result := make(chan error, 1)
+	go func() { result <- s.deliver(conn, msg) }()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		conn.Close()
+		<-result
+		return ctx.Err()
+	}
+}
+
+// deliver runs the full SMTP conversation over conn: greeting, EHLO,
+// optional STARTTLS, optional AUTH, envelope, DATA, and QUIT.
+func (s *SMTPSender) deliver(conn net.Conn, msg Message) error {
+	if containsCRLF(s.from) || containsCRLF(s.subject) || containsCRLF(msg.To()) {
+		return fmt.Errorf("smtp: from, subject, and recipient must not contain CR or LF")
+	}
+
+	if s.tlsMode == SMTPTLSImplicit {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.host})
+	}
+	defer func() { conn.Close() }()
+
+	text := textproto.NewConn(conn)
+	if _, _, err := text.ReadResponse(220); err != nil {
+		return smtpErrorFrom(err)
+	}
+
+	if err := s.ehlo(text); err != nil {
+		return err
+	}
+
+	if s.tlsMode == SMTPTLSStartTLS {
+		if err := s.cmd(text, 220, "STARTTLS"); err != nil {
+			return err
+		}
+		conn = tls.Client(conn, &tls.Config{ServerName: s.host})
+		text = textproto.NewConn(conn)
+		if err := s.ehlo(text); err != nil {
+			return err
+		}
+	}
+
+	if s.auth != SMTPAuthNone {
+		if err := s.authenticate(text); err != nil {
+			return err
+		}
+	}
+
+	if err := s.cmd(text, 250, "MAIL FROM:<%s>", s.from); err != nil {
+		return err
+	}
+	if err := s.cmd(text, 250, "RCPT TO:<%s>", msg.To()); err != nil {
+		return err
+	}
+	if err := s.cmd(text, 354, "DATA"); err != nil {
+		return err
+	}
+
+	w := text.DotWriter()
+	if _, err := w.Write([]byte(s.buildMessage(msg))); err != nil {
+		return fmt.Errorf("smtp: writing message body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp: closing message body: %w", err)
+	}
+	if _, _, err := text.ReadResponse(250); err != nil {
+		return smtpErrorFrom(err)
+	}
+
+	// QUIT is best-effort: the message has already been accepted, so a
+	// failure to close out the session cleanly is not a delivery failure.
+	if id, err := text.Cmd("QUIT"); err == nil {
+		text.StartResponse(id)
+		text.ReadResponse(221)
+		text.EndResponse(id)
+	}
+	return nil
+}
+
+// ehlo sends the EHLO greeting and expects a 250 response.
+func (s *SMTPSender) ehlo(text *textproto.Conn) error {
+	return s.cmd(text, 250, "EHLO %s", s.helo)
+}
+
+// cmd sends a single command built from format/args and expects expectCode
+// in response, translating any SMTP failure reply into a classified
+// *SMTPError.
+func (s *SMTPSender) cmd(text *textproto.Conn, expectCode int, format string, args ...any) error {
+	id, err := text.Cmd(format, args...)
+	if err != nil {
+		return fmt.Errorf("smtp: sending command: %w", err)
+	}
+	text.StartResponse(id)
+	defer text.EndResponse(id)
+	if _, _, err := text.ReadResponse(expectCode); err != nil {
+		return smtpErrorFrom(err)
+	}
+	return nil
+}
+
+// authenticate runs the AUTH exchange for the configured SMTPAuthType.
+func (s *SMTPSender) authenticate(text *textproto.Conn) error {
+	switch s.auth {
+	case SMTPAuthPlain:
+		payload := "\x00" + s.username + "\x00" + s.password
+		return s.cmd(text, 235, "AUTH PLAIN %s", base64.StdEncoding.EncodeToString([]byte(payload)))
+	case SMTPAuthLogin:
+		if err := s.cmd(text, 334, "AUTH LOGIN"); err != nil {
+			return err
+		}
+		if err := s.cmd(text, 334, "%s", base64.StdEncoding.EncodeToString([]byte(s.username))); err != nil {
+			return err
+		}
+		return s.cmd(text, 235, "%s", base64.StdEncoding.EncodeToString([]byte(s.password)))
+	case SMTPAuthCRAMMD5:
+		id, err := text.Cmd("AUTH CRAM-MD5")
+		if err != nil {
+			return fmt.Errorf("smtp: sending command: %w", err)
+		}
+		text.StartResponse(id)
+		_, challengeB64, err := text.ReadResponse(334)
+		text.EndResponse(id)
+		if err != nil {
+			return smtpErrorFrom(err)
+		}
+		challenge, err := base64.StdEncoding.DecodeString(challengeB64)
+		if err != nil {
+			return fmt.Errorf("smtp: decoding CRAM-MD5 challenge: %w", err)
+		}
+		mac := hmac.New(md5.New, []byte(s.password))
+		mac.Write(challenge)
+		response := fmt.Sprintf("%s %x", s.username, mac.Sum(nil))
+		return s.cmd(text, 235, "%s", base64.StdEncoding.EncodeToString([]byte(response)))
+	default:
+		return nil
+	}
+}
+
+// containsCRLF reports whether s contains a carriage return or line feed.
+// Any of from, subject, or a recipient address that reaches this point with
+// an embedded CR/LF could smuggle an extra SMTP command into the command
+// stream or an extra header into buildMessage's output, so deliver rejects
+// them up front rather than trusting callers to have sanitized their input.
+func containsCRLF(s string) bool {
+	return strings.ContainsAny(s, "\r\n")
+}
+
+// buildMessage renders msg as a minimal RFC 5322 message: From, To, and
+// Subject headers followed by the body, all with CRLF line endings.
+func (s *SMTPSender) buildMessage(msg Message) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To())
+	fmt.Fprintf(&b, "Subject: %s\r\n", s.subject)
+	b.WriteString("\r\n")
+	for _, line := range strings.Split(msg.Body(), "\n") {
+		b.WriteString(strings.TrimSuffix(line, "\r"))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,391 @@
+package examples
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// cramMD5Challenge is the fixed challenge fakeSMTPServer issues for AUTH
+// CRAM-MD5, so tests can independently compute the expected HMAC response.
+const cramMD5Challenge = "<fake.challenge@fake.test>"
+
+// fakeSMTPServer is a minimal SMTP server good enough to exercise
+// SMTPSender's wire protocol without any external network dependency. It
+// speaks plaintext only; TLS modes are exercised through configuration but
+// not round-tripped against this fake.
+type fakeSMTPServer struct {
+	ln               net.Listener
+	received         chan string
+	authPlainPayload chan string
+	cramMD5Response  chan string
+	failRCPT         *SMTPError
+	failLogin        bool
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := &fakeSMTPServer{
+		ln:               ln,
+		received:         make(chan string, 8),
+		authPlainPayload: make(chan string, 8),
+		cramMD5Response:  make(chan string, 8),
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go srv.serve(conn)
+		}
+	}()
+	return srv
+}
+
+func (f *fakeSMTPServer) addr() (string, int) {
+	tcpAddr := f.ln.Addr().(*net.TCPAddr)
+	return tcpAddr.IP.String(), tcpAddr.Port
+}
+
+func (f *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 fake.test ESMTP ready")
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		upper := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(upper, "EHLO"):
+			text.PrintfLine("250 fake.test")
+		case strings.HasPrefix(upper, "AUTH PLAIN"):
+			payloadB64 := strings.TrimSpace(line[len("AUTH PLAIN"):])
+			payload, err := base64.StdEncoding.DecodeString(payloadB64)
+			if err != nil {
+				text.PrintfLine("501 malformed AUTH PLAIN payload")
+				continue
+			}
+			f.authPlainPayload <- string(payload)
+			text.PrintfLine("235 authentication successful")
+		case strings.HasPrefix(upper, "AUTH CRAM-MD5"):
+			text.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte(cramMD5Challenge)))
+			responseLine, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			response, err := base64.StdEncoding.DecodeString(responseLine)
+			if err != nil {
+				text.PrintfLine("501 malformed AUTH CRAM-MD5 response")
+				continue
+			}
+			f.cramMD5Response <- string(response)
+			text.PrintfLine("235 authentication successful")
+		case strings.HasPrefix(upper, "AUTH LOGIN"):
+			if f.failLogin {
+				text.PrintfLine("535 authentication failed")
+				continue
+			}
+			text.PrintfLine("334 VXNlcm5hbWU6")
+			if _, err := text.ReadLine(); err != nil {
+				return
+			}
+			text.PrintfLine("334 UGFzc3dvcmQ6")
+			if _, err := text.ReadLine(); err != nil {
+				return
+			}
+			text.PrintfLine("235 authentication successful")
+		case strings.HasPrefix(upper, "MAIL FROM"):
+			text.PrintfLine("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO"):
+			if f.failRCPT != nil {
+				text.PrintfLine("%d %s", f.failRCPT.Code, f.failRCPT.Msg)
+				continue
+			}
+			text.PrintfLine("250 OK")
+		case upper == "DATA":
+			text.PrintfLine("354 Start mail input")
+			body, err := io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+			f.received <- string(body)
+			text.PrintfLine("250 OK")
+		case upper == "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("500 unrecognized command")
+		}
+	}
+}
+
+func (f *fakeSMTPServer) newSender(t *testing.T, opts ...SMTPOption) *SMTPSender {
+	t.Helper()
+	host, port := f.addr()
+	return NewSMTPSender(host, port, opts...)
+}
+
+func TestSMTPSenderDeliversMessage(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+	sender := srv.newSender(t, WithSMTPFrom("alerts@example.com"), WithSMTPSubject("Hi"))
+
+	msg, err := NewMessage("bob@example.com", "Hello Bob")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	select {
+	case body := <-srv.received:
+		for _, want := range []string{"From: alerts@example.com", "To: bob@example.com", "Subject: Hi", "Hello Bob"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("message body missing %q, got:\n%s", want, body)
+			}
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not receive message in time")
+	}
+}
+
+func TestSMTPSenderAuthLogin(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+	sender := srv.newSender(t, WithSMTPAuth(SMTPAuthLogin, "user", "pass"))
+
+	msg, err := NewMessage("bob@example.com", "Body")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	<-srv.received
+}
+
+func TestSMTPSenderRejectsCRLFInjection(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+
+	t.Run("RecipientWithInjectedRCPT", func(t *testing.T) {
+		_, err := NewMessage("victim@example.com>\r\nRCPT TO:<attacker@evil.com", "body")
+		if err == nil {
+			t.Fatal("NewMessage: expected error for CR/LF in to, got nil")
+		}
+	})
+
+	t.Run("FromWithInjectedHeader", func(t *testing.T) {
+		sender := srv.newSender(t, WithSMTPFrom("alerts@example.com\r\nBcc: attacker@evil.com"))
+		msg, err := NewMessage("bob@example.com", "body")
+		if err != nil {
+			t.Fatalf("NewMessage: %v", err)
+		}
+		if err := sender.Send(context.Background(), msg); err == nil {
+			t.Fatal("Send: expected error for CR/LF in from, got nil")
+		}
+	})
+
+	t.Run("SubjectWithInjectedHeader", func(t *testing.T) {
+		sender := srv.newSender(t, WithSMTPSubject("Hi\r\nBcc: attacker@evil.com"))
+		msg, err := NewMessage("bob@example.com", "body")
+		if err != nil {
+			t.Fatalf("NewMessage: %v", err)
+		}
+		if err := sender.Send(context.Background(), msg); err == nil {
+			t.Fatal("Send: expected error for CR/LF in subject, got nil")
+		}
+	})
+}
+
+func TestSMTPSenderAuthPlain(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+	sender := srv.newSender(t, WithSMTPAuth(SMTPAuthPlain, "user", "pass"))
+
+	msg, err := NewMessage("bob@example.com", "Body")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	<-srv.received
+
+	gotPayload := <-srv.authPlainPayload
+	wantPayload := "\x00user\x00pass"
+	if gotPayload != wantPayload {
+		t.Errorf("AUTH PLAIN payload = %q, want %q", gotPayload, wantPayload)
+	}
+}
+
+func TestSMTPSenderAuthCRAMMD5(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+	sender := srv.newSender(t, WithSMTPAuth(SMTPAuthCRAMMD5, "user", "pass"))
+
+	msg, err := NewMessage("bob@example.com", "Body")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	<-srv.received
+
+	got := <-srv.cramMD5Response
+	mac := hmac.New(md5.New, []byte("pass"))
+	mac.Write([]byte(cramMD5Challenge))
+	want := fmt.Sprintf("user %x", mac.Sum(nil))
+	if got != want {
+		t.Errorf("AUTH CRAM-MD5 response = %q, want %q", got, want)
+	}
+}
+
+func TestSMTPSenderAuthLoginFailureIsPermanent(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+	srv.failLogin = true
+	sender := srv.newSender(t, WithSMTPAuth(SMTPAuthLogin, "user", "pass"))
+
+	msg, err := NewMessage("bob@example.com", "Body")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	err = sender.Send(context.Background(), msg)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	var smtpErr *SMTPError
+	if !errors.As(err, &smtpErr) {
+		t.Fatalf("error is not *SMTPError: %v", err)
+	}
+	if smtpErr.Class != SMTPErrorPermanent {
+		t.Errorf("Class = %v, want SMTPErrorPermanent", smtpErr.Class)
+	}
+	if smtpErr.Temporary() {
+		t.Error("Temporary() = true, want false for a 5xx failure")
+	}
+}
+
+func TestSMTPSenderClassifiesTransientError(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+	srv.failRCPT = &SMTPError{Code: 450, Msg: "mailbox busy"}
+	sender := srv.newSender(t)
+
+	msg, err := NewMessage("bob@example.com", "Body")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	err = sender.Send(context.Background(), msg)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	var smtpErr *SMTPError
+	if !errors.As(err, &smtpErr) {
+		t.Fatalf("error is not *SMTPError: %v", err)
+	}
+	if smtpErr.Class != SMTPErrorTransient {
+		t.Errorf("Class = %v, want SMTPErrorTransient", smtpErr.Class)
+	}
+	if !smtpErr.Temporary() {
+		t.Error("Temporary() = false, want true for a 4xx failure")
+	}
+}
+
+func TestSMTPSenderContextCancellationDuringDial(t *testing.T) {
+	// A non-routable address (RFC 5737 TEST-NET-1 port that drops packets)
+	// lets the dial hang long enough for cancellation to win the race.
+	sender := NewSMTPSender("192.0.2.1", 25)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msg, err := NewMessage("bob@example.com", "Body")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	if err := sender.Send(ctx, msg); err == nil {
+		t.Fatal("expected error from canceled context, got nil")
+	}
+}
+
+func TestSMTPSenderContextCancellationDuringExchange(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	accepted := make(chan struct{})
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		close(accepted)
+		// Never reply, forcing the client to block on ReadResponse until
+		// ctx cancellation closes the connection out from under it.
+		<-context.Background().Done()
+		conn.Close()
+	}()
+
+	tcpAddr := ln.Addr().(*net.TCPAddr)
+	sender := NewSMTPSender(tcpAddr.IP.String(), tcpAddr.Port)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	msg, err := NewMessage("bob@example.com", "Body")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- sender.Send(ctx, msg) }()
+
+	<-accepted
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Send() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Send did not return promptly after context cancellation")
+	}
+}
+
+func TestSMTPErrorClassification(t *testing.T) {
+	tests := []struct {
+		name string
+		code int
+		want SMTPErrorClass
+	}{
+		{name: "TransientLowerBound", code: 400, want: SMTPErrorTransient},
+		{name: "TransientUpperBound", code: 499, want: SMTPErrorTransient},
+		{name: "PermanentLowerBound", code: 500, want: SMTPErrorPermanent},
+		{name: "PermanentUpperBound", code: 599, want: SMTPErrorPermanent},
+		{name: "SuccessIsUnknown", code: 250, want: SMTPErrorUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newSMTPError(tt.code, "msg")
+			if got.Class != tt.want {
+				t.Errorf("newSMTPError(%d).Class = %v, want %v", tt.code, got.Class, tt.want)
+			}
+		})
+	}
+}
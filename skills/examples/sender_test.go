@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/darrenoakey/claude-skill-golang/skills/errkit"
 )
 
 // TestSenderImplementations runs the same assertions against every Sender
@@ -30,6 +32,14 @@ func TestSenderImplementations(t *testing.T) {
 				return NewMemorySender()
 			},
 		},
+		{
+			name: "SMTPSender",
+			create: func(t *testing.T) Sender {
+				t.Helper()
+				srv := newFakeSMTPServer(t)
+				return srv.newSender(t)
+			},
+		},
 	}
 
 	for _, impl := range implementations {
@@ -75,6 +85,30 @@ func TestFileSenderWritesFile(t *testing.T) {
 	}
 }
 
+func TestFileSenderSendWriteFailureReturnsIOCode(t *testing.T) {
+	dir := t.TempDir()
+	// Point the sender at a path that is a file, not a directory, so the
+	// os.WriteFile inside Send fails and we can assert on the wrapped code.
+	blocker := filepath.Join(dir, "blocker")
+	if err := os.WriteFile(blocker, []byte("not a directory"), 0644); err != nil {
+		t.Fatalf("setting up blocker file: %v", err)
+	}
+
+	sender := NewFileSender(blocker)
+	msg, err := NewMessage("bob@example.com", "Body")
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+
+	err = sender.Send(context.Background(), msg)
+	if err == nil {
+		t.Fatal("expected error writing under a non-directory path, got nil")
+	}
+	if got := errkit.CodeOf(err); got != errkit.CodeIO {
+		t.Errorf("errkit.CodeOf(err) = %v, want %v", got, errkit.CodeIO)
+	}
+}
+
 func TestMemorySenderStoresMessages(t *testing.T) {
 	sender := NewMemorySender()
 	ctx := context.Background()
@@ -115,10 +149,16 @@ func TestNewMessageValidation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := NewMessage(tt.to, tt.body)
-			if tt.wantErr && err == nil {
-				t.Fatal("expected error, got nil")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if got := errkit.CodeOf(err); got != errkit.CodeInvalidInput {
+					t.Errorf("errkit.CodeOf(err) = %v, want %v", got, errkit.CodeInvalidInput)
+				}
+				return
 			}
-			if !tt.wantErr && err != nil {
+			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
 		})
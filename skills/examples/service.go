@@ -1,10 +1,11 @@
 package examples
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"log"
+
+	"github.com/darrenoakey/claude-skill-golang/skills/errkit"
 )
 
 // Logger defines the logging contract for Service. We define it at the
@@ -34,7 +35,7 @@ func NewService(logger Logger) *Service {
 // provides no useful audit trail.
 func (s *Service) ProcessOperation(input string) (string, error) {
 	if input == "" {
-		return "", errors.New("input cannot be empty")
+		return "", errkit.New(errkit.CodeInvalidInput, "input cannot be empty")
 	}
 	s.operationCount++
 	s.logger.Printf("Processing operation #%d: %s", s.operationCount, input)
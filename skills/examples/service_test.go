@@ -1,6 +1,10 @@
 package examples
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/darrenoakey/claude-skill-golang/skills/errkit"
+)
 
 // newTestService creates a Service suitable for testing with a no-op logger.
 func newTestService(t *testing.T) *Service {
@@ -39,6 +43,9 @@ func TestProcessOperationEmptyInputReturnsError(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for empty input, got nil")
 	}
+	if got := errkit.CodeOf(err); got != errkit.CodeInvalidInput {
+		t.Errorf("errkit.CodeOf(err) = %v, want %v", got, errkit.CodeInvalidInput)
+	}
 }
 
 func TestOperationCountStartsAtZero(t *testing.T) {
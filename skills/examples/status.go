@@ -1,5 +1,15 @@
 package examples
 
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/darrenoakey/claude-skill-golang/skills/errkit"
+)
+
 // Status represents the processing state of an order. We use an unexported
 // struct with exported variables instead of iota constants because this
 // pattern allows attaching behavior and multiple fields to each value,
@@ -45,3 +55,187 @@ var (
 func AllStatuses() []Status {
 	return []Status{StatusPending, StatusCompleted, StatusFailed}
 }
+
+// statusTransitions declares, for each status, which statuses it may move
+// to next. It is the single source of truth CanTransitionTo and Transition
+// consult, so adding a new status only means adding one entry here.
+var statusTransitions = map[Status][]Status{
+	StatusPending:   {StatusCompleted, StatusFailed},
+	StatusCompleted: {},
+	StatusFailed:    {},
+}
+
+// CanTransitionTo reports whether statusTransitions allows moving from s to
+// next.
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, candidate := range statusTransitions[s] {
+		if candidate == next {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidTransitionError reports an attempt to move a Status to a state
+// statusTransitions does not allow.
+type InvalidTransitionError struct {
+	From Status
+	To   Status
+}
+
+// Error implements the error interface.
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("invalid status transition from %s to %s", e.From, e.To)
+}
+
+// Transition moves s to next if statusTransitions allows it, running any
+// OnEnter hooks registered for next (via context.Background(), since
+// Transition itself takes no context) before reporting success. A disallowed
+// move returns an *InvalidTransitionError; a hook failure returns that
+// hook's error. Either way the returned Status is the zero value, so callers
+// must keep using s on failure rather than assuming the move took effect.
+func (s Status) Transition(next Status) (Status, error) {
+	if !s.CanTransitionTo(next) {
+		return Status{}, &InvalidTransitionError{From: s, To: next}
+	}
+	if err := runOnEnterHooks(context.Background(), next); err != nil {
+		return Status{}, err
+	}
+	return next, nil
+}
+
+var (
+	statusByValue = map[string]Status{}
+	statusByCode  = map[int]Status{}
+)
+
+func init() {
+	for _, s := range AllStatuses() {
+		statusByValue[s.value] = s
+		statusByCode[s.code] = s
+	}
+}
+
+// ParseStatus looks up the Status whose String() equals s.
+func ParseStatus(s string) (Status, error) {
+	status, ok := statusByValue[s]
+	if !ok {
+		return Status{}, errkit.New(errkit.CodeInvalidInput, fmt.Sprintf("unknown status %q", s))
+	}
+	return status, nil
+}
+
+// ParseStatusCode looks up the Status whose Code() equals code.
+func ParseStatusCode(code int) (Status, error) {
+	status, ok := statusByCode[code]
+	if !ok {
+		return Status{}, errkit.New(errkit.CodeInvalidInput, fmt.Sprintf("unknown status code %d", code))
+	}
+	return status, nil
+}
+
+// MarshalJSON encodes the status as its string value, e.g. "Pending", so it
+// reads naturally in a JSON API response.
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.value)
+}
+
+// UnmarshalJSON decodes a JSON string produced by MarshalJSON back into a
+// Status, rejecting any value ParseStatus doesn't recognize.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := ParseStatus(value)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, persisting the status as its numeric code
+// so the database column stays a small, stable integer rather than a string
+// that would break if a status's display name ever changed.
+func (s Status) Value() (driver.Value, error) {
+	return int64(s.code), nil
+}
+
+// Scan implements sql.Scanner, the inverse of Value.
+func (s *Status) Scan(src any) error {
+	var code int
+	switch v := src.(type) {
+	case int64:
+		code = int(v)
+	case int32:
+		code = int(v)
+	case int:
+		code = v
+	default:
+		return errkit.New(errkit.CodeInvalidInput, fmt.Sprintf("cannot scan %T into Status", src))
+	}
+
+	parsed, err := ParseStatusCode(code)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+type onEnterEntry struct {
+	id   int64
+	hook func(context.Context) error
+}
+
+var (
+	onEnterMu     sync.Mutex
+	onEnterHooks  = map[Status][]onEnterEntry{}
+	onEnterNextID int64
+)
+
+// OnEnter registers hook to run whenever Transition successfully moves a
+// Status into s. Hooks run in registration order; the first one to return
+// an error aborts the transition, and Transition surfaces that error
+// instead of completing the move. This is how services like the
+// notification Sender react to state changes without Status itself knowing
+// anything about notifications.
+//
+// OnEnter returns an unregister function that removes this hook again;
+// tests that register a hook should call it via t.Cleanup so hooks don't
+// leak into unrelated tests.
+func OnEnter(s Status, hook func(context.Context) error) (unregister func()) {
+	onEnterMu.Lock()
+	onEnterNextID++
+	id := onEnterNextID
+	onEnterHooks[s] = append(onEnterHooks[s], onEnterEntry{id: id, hook: hook})
+	onEnterMu.Unlock()
+
+	return func() {
+		onEnterMu.Lock()
+		defer onEnterMu.Unlock()
+		entries := onEnterHooks[s]
+		for i, e := range entries {
+			if e.id == id {
+				onEnterHooks[s] = append(entries[:i:i], entries[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// runOnEnterHooks invokes the hooks registered for s in registration order,
+// stopping at the first error.
+func runOnEnterHooks(ctx context.Context, s Status) error {
+	onEnterMu.Lock()
+	entries := append([]onEnterEntry(nil), onEnterHooks[s]...)
+	onEnterMu.Unlock()
+
+	for _, e := range entries {
+		if err := e.hook(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
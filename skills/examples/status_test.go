@@ -1,6 +1,11 @@
 package examples
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
 
 func TestStatusIsTerminal(t *testing.T) {
 	tests := []struct {
@@ -77,3 +82,204 @@ func TestAllStatusesReturnsAllValues(t *testing.T) {
 		t.Errorf("AllStatuses()[2] = %v, want Failed", all[2])
 	}
 }
+
+func TestStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from Status
+		to   Status
+		want bool
+	}{
+		{name: "PendingToCompletedAllowed", from: StatusPending, to: StatusCompleted, want: true},
+		{name: "PendingToFailedAllowed", from: StatusPending, to: StatusFailed, want: true},
+		{name: "PendingToPendingDisallowed", from: StatusPending, to: StatusPending, want: false},
+		{name: "CompletedToPendingDisallowed", from: StatusCompleted, to: StatusPending, want: false},
+		{name: "CompletedIsTerminal", from: StatusCompleted, to: StatusFailed, want: false},
+		{name: "FailedIsTerminal", from: StatusFailed, to: StatusCompleted, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.from.CanTransitionTo(tt.to)
+			if got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusTransitionValid(t *testing.T) {
+	got, err := StatusPending.Transition(StatusCompleted)
+	if err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if got != StatusCompleted {
+		t.Errorf("Transition returned %v, want %v", got, StatusCompleted)
+	}
+}
+
+func TestStatusTransitionInvalid(t *testing.T) {
+	_, err := StatusCompleted.Transition(StatusPending)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	var invalidErr *InvalidTransitionError
+	if !errors.As(err, &invalidErr) {
+		t.Fatalf("error is not *InvalidTransitionError: %v", err)
+	}
+	if invalidErr.From != StatusCompleted || invalidErr.To != StatusPending {
+		t.Errorf("InvalidTransitionError = {From: %v, To: %v}, want {From: %v, To: %v}",
+			invalidErr.From, invalidErr.To, StatusCompleted, StatusPending)
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Status
+		wantErr bool
+	}{
+		{name: "Pending", input: "Pending", want: StatusPending},
+		{name: "Completed", input: "Completed", want: StatusCompleted},
+		{name: "Failed", input: "Failed", want: StatusFailed},
+		{name: "Unknown", input: "Bogus", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatus(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseStatus(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStatusCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   int
+		want    Status
+		wantErr bool
+	}{
+		{name: "Pending", input: 1, want: StatusPending},
+		{name: "Completed", input: 2, want: StatusCompleted},
+		{name: "Failed", input: 3, want: StatusFailed},
+		{name: "Unknown", input: 99, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatusCode(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseStatusCode(%d) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusJSONRoundTrip(t *testing.T) {
+	for _, want := range AllStatuses() {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", want, err)
+		}
+		if string(data) != `"`+want.String()+`"` {
+			t.Errorf("Marshal(%v) = %s, want %q", want, data, want.String())
+		}
+
+		var got Status
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if got != want {
+			t.Errorf("round trip of %v produced %v", want, got)
+		}
+	}
+}
+
+func TestStatusUnmarshalJSONRejectsUnknownValue(t *testing.T) {
+	var s Status
+	if err := json.Unmarshal([]byte(`"Bogus"`), &s); err == nil {
+		t.Fatal("expected error for unknown status, got nil")
+	}
+}
+
+func TestStatusSQLValueAndScan(t *testing.T) {
+	for _, want := range AllStatuses() {
+		value, err := want.Value()
+		if err != nil {
+			t.Fatalf("Value(%v): %v", want, err)
+		}
+		if value != int64(want.Code()) {
+			t.Errorf("Value(%v) = %v, want %d", want, value, want.Code())
+		}
+
+		var got Status
+		if err := got.Scan(value); err != nil {
+			t.Fatalf("Scan(%v): %v", value, err)
+		}
+		if got != want {
+			t.Errorf("Scan(%v) produced %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestStatusScanRejectsUnsupportedType(t *testing.T) {
+	var s Status
+	if err := s.Scan("not a code"); err == nil {
+		t.Fatal("expected error scanning a string, got nil")
+	}
+}
+
+func TestOnEnterHookInvocationOrdering(t *testing.T) {
+	var order []string
+	t.Cleanup(OnEnter(StatusFailed, func(context.Context) error {
+		order = append(order, "first")
+		return nil
+	}))
+	t.Cleanup(OnEnter(StatusFailed, func(context.Context) error {
+		order = append(order, "second")
+		return nil
+	}))
+
+	if _, err := StatusPending.Transition(StatusFailed); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+
+	if len(order) < 2 {
+		t.Fatalf("hooks recorded %v, want at least 2 entries", order)
+	}
+	last := order[len(order)-2:]
+	if last[0] != "first" || last[1] != "second" {
+		t.Errorf("hooks ran in order %v, want [first second]", last)
+	}
+}
+
+func TestOnEnterHookErrorAbortsTransition(t *testing.T) {
+	hookErr := errors.New("notification failed")
+	t.Cleanup(OnEnter(StatusCompleted, func(context.Context) error {
+		return hookErr
+	}))
+
+	_, err := StatusPending.Transition(StatusCompleted)
+	if !errors.Is(err, hookErr) {
+		t.Fatalf("Transition error = %v, want %v", err, hookErr)
+	}
+}
@@ -0,0 +1,141 @@
+package text
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// SlugOptions customizes SlugifyWith beyond Slugify's defaults.
+type SlugOptions struct {
+	// Separator replaces each run of non-slug runes. Defaults to "-" when
+	// empty.
+	Separator string
+	// MaxLen truncates the result on a separator boundary so a word is
+	// never cut in half. Zero means unlimited.
+	MaxLen int
+	// PreserveCase skips lowercasing, so uppercase ASCII letters are kept
+	// as part of the allowed rune set instead of being folded to lowercase.
+	PreserveCase bool
+	// Transliterate controls what happens to runes that survive NFD
+	// decomposition but are still outside ASCII (CJK ideographs, Cyrillic,
+	// etc). By default such runes are dropped silently, same as any other
+	// disallowed character. When true, each one is instead replaced with a
+	// stable "u<hex codepoint>" token so that two different non-Latin
+	// inputs don't collide into the same (or an empty) slug. This is not
+	// real transliteration - there is no pronunciation or meaning mapping
+	// here - just a deterministic, collision-resistant placeholder.
+	Transliterate bool
+}
+
+// Slugify converts s into a URL- and filename-safe slug: lowercased,
+// transliterated to ASCII (accented Latin letters lose their diacritics),
+// with every run of characters outside [a-z0-9] collapsed to a single "-",
+// and leading/trailing hyphens trimmed. It is equivalent to
+// SlugifyWith(s, SlugOptions{}).
+func Slugify(s string) string {
+	return SlugifyWith(s, SlugOptions{})
+}
+
+// SlugifyWith is Slugify with a customizable separator, max length, and
+// case handling. See SlugOptions for details on each field.
+func SlugifyWith(s string, opts SlugOptions) string {
+	sep := opts.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	folded := foldToASCII(s, opts.Transliterate)
+	if !opts.PreserveCase {
+		folded = strings.ToLower(folded)
+	}
+
+	words := splitIntoSlugWords(folded, opts.PreserveCase)
+	if opts.MaxLen > 0 {
+		words = wordsWithinMaxLen(words, sep, opts.MaxLen)
+	}
+	return strings.Join(words, sep)
+}
+
+// foldToASCII runs s through NFD (canonical) decomposition and drops every
+// combining mark (unicode.Mn) that decomposition exposed, which is how an
+// accented Latin letter like "é" becomes plain "e". Runes that remain
+// outside ASCII afterward - CJK ideographs, undecomposed Cyrillic, and
+// similar - are dropped, unless transliterate requests the placeholder
+// fallback described on SlugOptions.Transliterate.
+func foldToASCII(s string, transliterate bool) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining mark: the base rune it modifies was already
+			// written by the previous iteration, so just drop the mark.
+		case r <= unicode.MaxASCII:
+			b.WriteRune(r)
+		case transliterate:
+			fmt.Fprintf(&b, "u%x", r)
+		}
+	}
+	return b.String()
+}
+
+// splitIntoSlugWords extracts the maximal runs of allowed slug characters
+// from folded, discarding everything else as a word boundary.
+func splitIntoSlugWords(folded string, preserveCase bool) []string {
+	var words []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range folded {
+		if isSlugRune(r, preserveCase) {
+			current.WriteRune(r)
+		} else {
+			flush()
+		}
+	}
+	flush()
+	return words
+}
+
+// isSlugRune reports whether r may appear unescaped in a slug: digits and
+// lowercase ASCII letters always, uppercase ASCII letters only when
+// preserveCase keeps them from being folded to lowercase first.
+func isSlugRune(r rune, preserveCase bool) bool {
+	switch {
+	case r >= '0' && r <= '9':
+		return true
+	case r >= 'a' && r <= 'z':
+		return true
+	case preserveCase && r >= 'A' && r <= 'Z':
+		return true
+	default:
+		return false
+	}
+}
+
+// wordsWithinMaxLen returns the longest prefix of words that joins with sep
+// to at most maxLen bytes, so truncation always lands on a word boundary
+// rather than splitting one. A single word longer than maxLen on its own is
+// dropped entirely, since it cannot be shortened without cutting it.
+func wordsWithinMaxLen(words []string, sep string, maxLen int) []string {
+	var kept []string
+	length := 0
+	for _, w := range words {
+		add := len(w)
+		if len(kept) > 0 {
+			add += len(sep)
+		}
+		if length+add > maxLen {
+			break
+		}
+		kept = append(kept, w)
+		length += add
+	}
+	return kept
+}
@@ -0,0 +1,71 @@
+package text
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "SimpleLowercase", input: "hello world", want: "hello-world"},
+		{name: "MixedCaseLowercased", input: "Hello World", want: "hello-world"},
+		{name: "AccentedLatinTransliterated", input: "Café del Mar", want: "cafe-del-mar"},
+		{name: "PunctuationCollapsedToOneSeparator", input: "Hello, World!!!", want: "hello-world"},
+		{name: "LeadingTrailingSeparatorsTrimmed", input: "  --Hello World--  ", want: "hello-world"},
+		{name: "RepeatedInternalWhitespaceCollapsed", input: "Hello     World", want: "hello-world"},
+		{name: "EmptyStringIsEmpty", input: "", want: ""},
+		{name: "OnlyPunctuationIsEmpty", input: "!!!", want: ""},
+		{name: "CombiningMarkInputTransliterated", input: "résumé", want: "resume"},
+		{name: "CyrillicWithoutTransliterateIsEmpty", input: "Привет", want: ""},
+		{name: "CJKWithoutTransliterateIsEmpty", input: "你好", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slugify(tt.input)
+			if got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlugifyWithTransliterateKeepsCJKNonEmpty(t *testing.T) {
+	got := SlugifyWith("你好", SlugOptions{Transliterate: true})
+	if got == "" {
+		t.Fatal("SlugifyWith(..., Transliterate: true) = \"\", want a non-empty placeholder slug")
+	}
+
+	// Different CJK input must not collapse to the same placeholder slug.
+	other := SlugifyWith("世界", SlugOptions{Transliterate: true})
+	if got == other {
+		t.Errorf("two different CJK inputs produced the same slug %q", got)
+	}
+}
+
+func TestSlugifyWithCustomSeparator(t *testing.T) {
+	got := SlugifyWith("Hello World", SlugOptions{Separator: "_"})
+	want := "hello_world"
+	if got != want {
+		t.Errorf("SlugifyWith with custom separator = %q, want %q", got, want)
+	}
+}
+
+func TestSlugifyWithPreserveCase(t *testing.T) {
+	got := SlugifyWith("Hello World", SlugOptions{PreserveCase: true})
+	want := "Hello-World"
+	if got != want {
+		t.Errorf("SlugifyWith with PreserveCase = %q, want %q", got, want)
+	}
+}
+
+func TestSlugifyWithMaxLenTruncatesOnWordBoundary(t *testing.T) {
+	got := SlugifyWith("the quick brown fox jumps", SlugOptions{MaxLen: 15})
+	want := "the-quick-brown"
+	if got != want {
+		t.Errorf("SlugifyWith with MaxLen = %q, want %q", got, want)
+	}
+	if len(got) > 15 {
+		t.Errorf("result %q is %d bytes, want <= 15", got, len(got))
+	}
+}
@@ -0,0 +1,66 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/darrenoakey/claude-skill-golang/skills/errkit"
+)
+
+// Truncate shortens s to maxLen runes, adding an ellipsis if truncation
+// occurs. Runes rather than bytes are counted and sliced, so multibyte
+// input like "héllo wörld" truncates without splitting a character in half.
+// We require maxLen >= 4 so there is always room for at least one character
+// plus the "..." suffix. Callers that need the old byte-counting behavior
+// can use TruncateBytes.
+func Truncate(s string, maxLen int) (string, error) {
+	if maxLen < 4 {
+		return "", errkit.New(errkit.CodeInvalidInput, "maxLen must be at least 4 to accommodate ellipsis")
+	}
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s, nil
+	}
+	return string(runes[:maxLen-3]) + "...", nil
+}
+
+// TruncateBytes shortens s to maxLen bytes, adding an ellipsis if truncation
+// occurs. This is Truncate's original byte-counting behavior, kept for
+// callers operating on byte-oriented limits (wire formats, fixed-width
+// columns) where slicing by rune would be wrong. Like Truncate, it requires
+// maxLen >= 4 and can split a multibyte rune if the cut falls inside one.
+func TruncateBytes(s string, maxLen int) (string, error) {
+	if maxLen < 4 {
+		return "", errkit.New(errkit.CodeInvalidInput, "maxLen must be at least 4 to accommodate ellipsis")
+	}
+	if len(s) <= maxLen {
+		return s, nil
+	}
+	return s[:maxLen-3] + "...", nil
+}
+
+// IsBlank reports whether s is empty or contains only whitespace. This
+// centralizes a check that appears throughout codebases so every caller
+// uses the same definition of "blank."
+func IsBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+// ToTitleCase converts s to title case, capitalizing the first letter of
+// each word and lowercasing the rest. We handle this ourselves rather than
+// using strings.Title (deprecated) to avoid surprising behavior with
+// apostrophes and Unicode edge cases.
+func ToTitleCase(s string) string {
+	if IsBlank(s) {
+		return s
+	}
+	words := strings.Fields(s)
+	for i, w := range words {
+		runes := []rune(strings.ToLower(w))
+		if len(runes) > 0 {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
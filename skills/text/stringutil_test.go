@@ -1,6 +1,10 @@
 package text
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/darrenoakey/claude-skill-golang/skills/errkit"
+)
 
 func TestTruncate(t *testing.T) {
 	tests := []struct {
@@ -16,6 +20,8 @@ func TestTruncate(t *testing.T) {
 		{name: "EmptyStringUnchanged", input: "", maxLen: 10, want: ""},
 		{name: "MaxLenTooSmallReturnsError", input: "Hello", maxLen: 3, wantErr: true},
 		{name: "MaxLenExactlyFourTruncates", input: "Hello", maxLen: 4, want: "H..."},
+		{name: "MultibyteStringTruncatedByRune", input: "héllo wörld", maxLen: 8, want: "héllo..."},
+		{name: "MultibyteStringUnderLimitUnchanged", input: "héllo", maxLen: 10, want: "héllo"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -24,6 +30,9 @@ func TestTruncate(t *testing.T) {
 				if err == nil {
 					t.Fatal("expected error, got nil")
 				}
+				if got := errkit.CodeOf(err); got != errkit.CodeInvalidInput {
+					t.Errorf("errkit.CodeOf(err) = %v, want %v", got, errkit.CodeInvalidInput)
+				}
 				return
 			}
 			if err != nil {
@@ -36,6 +45,44 @@ func TestTruncate(t *testing.T) {
 	}
 }
 
+func TestTruncateBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		maxLen  int
+		want    string
+		wantErr bool
+	}{
+		{name: "ShortStringUnchanged", input: "Hello", maxLen: 10, want: "Hello"},
+		{name: "LongStringTruncated", input: "Hello World", maxLen: 8, want: "Hello..."},
+		{name: "MaxLenTooSmallReturnsError", input: "Hello", maxLen: 3, wantErr: true},
+		// maxLen - 3 = 2 bytes of "héllo" lands inside the 2-byte encoding
+		// of "é", producing an invalid UTF-8 tail byte. This is exactly the
+		// byte-unsafe behavior TruncateBytes documents and Truncate fixes.
+		{name: "CountsBytesNotRunesAndMaySplitARune", input: "héllo", maxLen: 5, want: "h\xc3..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TruncateBytes(tt.input, tt.maxLen)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if got := errkit.CodeOf(err); got != errkit.CodeInvalidInput {
+					t.Errorf("errkit.CodeOf(err) = %v, want %v", got, errkit.CodeInvalidInput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TruncateBytes(%q, %d) = %q, want %q", tt.input, tt.maxLen, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsBlank(t *testing.T) {
 	tests := []struct {
 		name  string